@@ -0,0 +1,295 @@
+package forms
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// tag names recognized by Bind and Render
+const (
+	tagForm     = "form"
+	tagValidate = "validate"
+	tagWidget   = "widget"
+)
+
+var emailRegexp = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+
+// Bind parses the given request into v, a pointer to a struct whose fields
+// are annotated with `form`, `validate` and `widget` tags, and runs the
+// validators declared in the `validate` tag against the submitted values.
+// It returns a FormErrors with one entry per field that failed to bind or
+// validate, or nil if everything passed.
+func Bind(r *http.Request, v any) FormErrors {
+	if r == nil {
+		return nil
+	}
+	if err := r.ParseForm(); err != nil {
+		return nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	errs := FormErrors{}
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		fv := rv.Field(i)
+		if !fv.CanSet() {
+			continue
+		}
+
+		name := fieldName(sf)
+		raw := r.FormValue(name)
+
+		if err := setFieldValue(fv, raw); err != nil {
+			errs[name] = NewFieldError(name, err)
+			continue
+		}
+
+		if err := runValidators(sf, fv, name, raw); err != nil {
+			errs[name] = NewFieldError(name, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Render walks v, a pointer to (or value of) a struct annotated with `form`
+// and `widget` tags, and returns the HTML markup produced by the Field each
+// struct field maps to, populated with the struct's current values.
+func Render(v any) template.HTML {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return ""
+	}
+	rt := rv.Type()
+
+	var b strings.Builder
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		fv := rv.Field(i)
+
+		name := fieldName(sf)
+		field := widgetField(sf, name)
+		field.SetValue(fmt.Sprint(fv.Interface()))
+		b.WriteString(field.String())
+	}
+	return template.HTML(b.String())
+}
+
+// fieldName returns the form field name for the given struct field,
+// honoring the `form` tag and falling back to the lowercased field name.
+func fieldName(sf reflect.StructField) string {
+	if tag := sf.Tag.Get(tagForm); tag != "" {
+		return tag
+	}
+	return strings.ToLower(sf.Name)
+}
+
+// attrSettable is implemented by every *Input-embedding field; widgetField
+// uses it to apply leftover `key=value` widget-tag parts regardless of which
+// concrete constructor it dispatched to.
+type attrSettable interface {
+	AddAttr(key, value string) *Input
+}
+
+// widgetField builds the Field for sf according to its `widget` tag,
+// defaulting to a plain text Input when the tag is absent. Parts after the
+// kind are either `option=value:Label` pairs, collected as Options for
+// select/radio widgets, or plain `key=value` attributes applied to the
+// field.
+func widgetField(sf reflect.StructField, name string) Field {
+	parts := strings.Split(sf.Tag.Get(tagWidget), ",")
+	kind := strings.TrimSpace(parts[0])
+
+	var options []Option
+	var attrs [][2]string
+	for _, part := range parts[1:] {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		if key == "option" {
+			optValue, label, _ := strings.Cut(value, ":")
+			options = append(options, Option{Value: optValue, Label: label})
+			continue
+		}
+		attrs = append(attrs, [2]string{key, value})
+	}
+
+	var field Field
+	switch kind {
+	case "int", "integer":
+		field = NewIntegerInput()
+	case "select":
+		field = NewSelectInput(options...)
+	case "radio":
+		field = NewRadioInput(options...)
+	case "checkbox":
+		field = NewCheckboxInput()
+	case "textarea":
+		field = NewTextareaField()
+	default:
+		input := NewInput()
+		if kind != "" {
+			input.typ = kind
+		}
+		field = input
+	}
+	field.SetName(name)
+
+	if settable, ok := field.(attrSettable); ok {
+		for _, attr := range attrs {
+			settable.AddAttr(attr[0], attr[1])
+		}
+	}
+	return field
+}
+
+// setFieldValue assigns the parsed form value raw to fv, converting it to
+// fv's underlying kind.
+func setFieldValue(fv reflect.Value, raw string) error {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if raw == "" {
+			return nil
+		}
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return errors.New("Not a valid integer.")
+		}
+		fv.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		if raw == "" {
+			return nil
+		}
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return errors.New("Not a valid number.")
+		}
+		fv.SetFloat(n)
+	case reflect.Bool:
+		fv.SetBool(raw == "on" || raw == "true" || raw == "1")
+	}
+	return nil
+}
+
+// runValidators applies the rules declared in sf's `validate` tag to raw,
+// returning the first failure. fv is the bound struct field, so numeric
+// rules (min, max, len) can compare the field's actual value instead of
+// always falling back to the submitted string's length.
+func runValidators(sf reflect.StructField, fv reflect.Value, name, raw string) error {
+	tag := sf.Tag.Get(tagValidate)
+	if tag == "" {
+		return nil
+	}
+	for _, rule := range strings.Split(tag, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		key, param, _ := strings.Cut(rule, "=")
+		if err := applyRule(key, param, name, raw, fv.Kind()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isNumericKind reports whether kind is one of the int/float kinds
+// setFieldValue coerces form values into.
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// applyRule runs a single named validate rule (required, email, url, min,
+// max, len, int, regexp) against raw. For numeric struct fields, min/max/len
+// compare the field's numeric value; for every other kind they compare
+// len(raw), matching go-playground/validator's behavior.
+func applyRule(rule, param, name, raw string, kind reflect.Kind) error {
+	switch rule {
+	case "required":
+		if raw == "" {
+			return fmt.Errorf("%s is required.", name)
+		}
+	case "email":
+		if raw != "" && !emailRegexp.MatchString(raw) {
+			return fmt.Errorf("%s is not a valid email.", name)
+		}
+	case "url":
+		if raw != "" {
+			if _, err := url.ParseRequestURI(raw); err != nil {
+				return fmt.Errorf("%s is not a valid url.", name)
+			}
+		}
+	case "int":
+		if raw != "" {
+			if _, err := strconv.ParseInt(raw, 10, 64); err != nil {
+				return fmt.Errorf("%s is not a valid integer.", name)
+			}
+		}
+	case "len":
+		n, _ := strconv.Atoi(param)
+		if isNumericKind(kind) {
+			v, err := strconv.ParseFloat(raw, 64)
+			if err == nil && v != float64(n) {
+				return fmt.Errorf("%s must equal %d.", name, n)
+			}
+		} else if len(raw) != n {
+			return fmt.Errorf("%s must be exactly %d characters.", name, n)
+		}
+	case "min":
+		n, _ := strconv.Atoi(param)
+		if isNumericKind(kind) {
+			v, err := strconv.ParseFloat(raw, 64)
+			if err == nil && v < float64(n) {
+				return fmt.Errorf("%s must be at least %d.", name, n)
+			}
+		} else if len(raw) < n {
+			return fmt.Errorf("%s must be at least %d characters.", name, n)
+		}
+	case "max":
+		n, _ := strconv.Atoi(param)
+		if isNumericKind(kind) {
+			v, err := strconv.ParseFloat(raw, 64)
+			if err == nil && v > float64(n) {
+				return fmt.Errorf("%s must be at most %d.", name, n)
+			}
+		} else if len(raw) > n {
+			return fmt.Errorf("%s must be at most %d characters.", name, n)
+		}
+	case "regexp":
+		re, err := regexp.Compile(param)
+		if err != nil {
+			return fmt.Errorf("%s has an invalid regexp rule.", name)
+		}
+		if raw != "" && !re.MatchString(raw) {
+			return fmt.Errorf("%s does not match the required format.", name)
+		}
+	}
+	return nil
+}
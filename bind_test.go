@@ -0,0 +1,171 @@
+package forms
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func bindRequest(t *testing.T, values url.Values) *http.Request {
+	t.Helper()
+	r, err := http.NewRequest(http.MethodPost, "/", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	r.PostForm = values
+	r.Form = values
+	return r
+}
+
+func TestBindNumericMinMaxComparesValueNotStringLength(t *testing.T) {
+	type payload struct {
+		Age int `form:"age" validate:"min=18,max=130"`
+	}
+
+	var p payload
+	errs := Bind(bindRequest(t, url.Values{"age": {"25"}}), &p)
+	if errs != nil {
+		t.Fatalf("age=25 should satisfy min=18,max=130, got %v", errs)
+	}
+	if p.Age != 25 {
+		t.Fatalf("expected Age to be bound to 25, got %d", p.Age)
+	}
+
+	errs = Bind(bindRequest(t, url.Values{"age": {"5"}}), &p)
+	if errs == nil || errs["age"] == nil {
+		t.Fatal("age=5 should fail min=18")
+	}
+
+	errs = Bind(bindRequest(t, url.Values{"age": {"999"}}), &p)
+	if errs == nil || errs["age"] == nil {
+		t.Fatal("age=999 should fail max=130")
+	}
+}
+
+func TestBindStringMinMaxStillComparesLength(t *testing.T) {
+	type payload struct {
+		Name string `form:"name" validate:"min=2,max=10"`
+	}
+
+	var p payload
+	if errs := Bind(bindRequest(t, url.Values{"name": {"a"}}), &p); errs == nil || errs["name"] == nil {
+		t.Fatal("a single-character name should fail min=2")
+	}
+	if errs := Bind(bindRequest(t, url.Values{"name": {"abcdefghijk"}}), &p); errs == nil || errs["name"] == nil {
+		t.Fatal("an 11-character name should fail max=10")
+	}
+	if errs := Bind(bindRequest(t, url.Values{"name": {"abc"}}), &p); errs != nil {
+		t.Fatalf("a 3-character name should satisfy min=2,max=10, got %v", errs)
+	}
+}
+
+func TestBindRequired(t *testing.T) {
+	type payload struct {
+		Name string `form:"name" validate:"required"`
+	}
+	var p payload
+	if errs := Bind(bindRequest(t, url.Values{"name": {""}}), &p); errs == nil || errs["name"] == nil {
+		t.Fatal("empty required field should fail")
+	}
+	if errs := Bind(bindRequest(t, url.Values{"name": {"x"}}), &p); errs != nil {
+		t.Fatalf("non-empty required field should pass, got %v", errs)
+	}
+}
+
+func TestBindEmail(t *testing.T) {
+	type payload struct {
+		Email string `form:"email" validate:"email"`
+	}
+	var p payload
+	if errs := Bind(bindRequest(t, url.Values{"email": {"not-an-email"}}), &p); errs == nil || errs["email"] == nil {
+		t.Fatal("invalid email should fail")
+	}
+	if errs := Bind(bindRequest(t, url.Values{"email": {"a@b.com"}}), &p); errs != nil {
+		t.Fatalf("valid email should pass, got %v", errs)
+	}
+}
+
+func TestBindURL(t *testing.T) {
+	type payload struct {
+		Site string `form:"site" validate:"url"`
+	}
+	var p payload
+	if errs := Bind(bindRequest(t, url.Values{"site": {"://broken"}}), &p); errs == nil || errs["site"] == nil {
+		t.Fatal("invalid URL should fail")
+	}
+	if errs := Bind(bindRequest(t, url.Values{"site": {"https://example.com"}}), &p); errs != nil {
+		t.Fatalf("valid URL should pass, got %v", errs)
+	}
+}
+
+func TestBindLen(t *testing.T) {
+	type payload struct {
+		Code string `form:"code" validate:"len=4"`
+	}
+	var p payload
+	if errs := Bind(bindRequest(t, url.Values{"code": {"abc"}}), &p); errs == nil || errs["code"] == nil {
+		t.Fatal("code with wrong length should fail")
+	}
+	if errs := Bind(bindRequest(t, url.Values{"code": {"abcd"}}), &p); errs != nil {
+		t.Fatalf("code with exact length should pass, got %v", errs)
+	}
+}
+
+func TestBindIntRule(t *testing.T) {
+	type payload struct {
+		Count string `form:"count" validate:"int"`
+	}
+	var p payload
+	if errs := Bind(bindRequest(t, url.Values{"count": {"abc"}}), &p); errs == nil || errs["count"] == nil {
+		t.Fatal("non-integer string should fail the int rule")
+	}
+	if errs := Bind(bindRequest(t, url.Values{"count": {"42"}}), &p); errs != nil {
+		t.Fatalf("integer string should pass the int rule, got %v", errs)
+	}
+}
+
+func TestBindRegexp(t *testing.T) {
+	type payload struct {
+		Slug string `form:"slug" validate:"regexp=^[a-z]+$"`
+	}
+	var p payload
+	if errs := Bind(bindRequest(t, url.Values{"slug": {"ABC"}}), &p); errs == nil || errs["slug"] == nil {
+		t.Fatal("slug not matching the regexp should fail")
+	}
+	if errs := Bind(bindRequest(t, url.Values{"slug": {"abc"}}), &p); errs != nil {
+		t.Fatalf("slug matching the regexp should pass, got %v", errs)
+	}
+}
+
+func TestBindTypeCoercion(t *testing.T) {
+	type payload struct {
+		Name   string
+		Age    int
+		Rating float64
+		Active bool
+	}
+	var p payload
+	errs := Bind(bindRequest(t, url.Values{
+		"name":   {"Ada"},
+		"age":    {"36"},
+		"rating": {"4.5"},
+		"active": {"on"},
+	}), &p)
+	if errs != nil {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+	if p.Name != "Ada" || p.Age != 36 || p.Rating != 4.5 || !p.Active {
+		t.Fatalf("unexpected bound payload: %+v", p)
+	}
+}
+
+func TestBindInvalidIntReturnsFieldError(t *testing.T) {
+	type payload struct {
+		Age int `form:"age"`
+	}
+	var p payload
+	errs := Bind(bindRequest(t, url.Values{"age": {"not-a-number"}}), &p)
+	if errs == nil || errs["age"] == nil {
+		t.Fatal("non-numeric value for an int field should fail to bind")
+	}
+}
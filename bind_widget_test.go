@@ -0,0 +1,38 @@
+package forms
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWidgetFieldDispatchesSelectWithOptions(t *testing.T) {
+	type formStruct struct {
+		Color string `form:"color" widget:"select,option=r:Red,option=b:Blue"`
+	}
+	html := string(Render(&formStruct{Color: "b"}))
+
+	if !strings.Contains(html, "<select") {
+		t.Fatalf("expected a <select> element, got: %s", html)
+	}
+	if !strings.Contains(html, "<option value='r'>Red</option>") {
+		t.Fatalf("expected option r=Red, got: %s", html)
+	}
+	if !strings.Contains(html, "<option value='b' selected>Blue</option>") {
+		t.Fatalf("expected option b=Blue selected, got: %s", html)
+	}
+}
+
+func TestWidgetFieldDispatchesRadioAndTextarea(t *testing.T) {
+	type formStruct struct {
+		Size string `form:"size" widget:"radio,option=s:Small,option=l:Large"`
+		Bio  string `form:"bio" widget:"textarea"`
+	}
+	html := string(Render(&formStruct{Size: "l", Bio: "hi"}))
+
+	if !strings.Contains(html, "type='radio'") {
+		t.Fatalf("expected radio inputs, got: %s", html)
+	}
+	if !strings.Contains(html, "<textarea") {
+		t.Fatalf("expected a <textarea> element, got: %s", html)
+	}
+}
@@ -0,0 +1,46 @@
+package forms
+
+import "fmt"
+
+// RequiredIf returns a validator that fails when the field is empty and the
+// sibling field named otherField currently holds otherValue.
+func RequiredIf(otherField, otherValue string) ValidatorFunc {
+	return func(f Field, form *Form) error {
+		if f.Value() != "" {
+			return nil
+		}
+		sibling, ok := form.fields[otherField]
+		if !ok || sibling.Value() != otherValue {
+			return nil
+		}
+		return fmt.Errorf("%s is required.", f.Name())
+	}
+}
+
+// RequiredUnless returns a validator that fails when the field is empty and
+// the sibling field named otherField does not currently hold otherValue.
+func RequiredUnless(otherField, otherValue string) ValidatorFunc {
+	return func(f Field, form *Form) error {
+		if f.Value() != "" {
+			return nil
+		}
+		sibling, ok := form.fields[otherField]
+		if ok && sibling.Value() == otherValue {
+			return nil
+		}
+		return fmt.Errorf("%s is required.", f.Name())
+	}
+}
+
+// EqualToField returns a validator that fails unless the field's value
+// matches the sibling field named otherField, useful for password
+// confirmation.
+func EqualToField(otherField string) ValidatorFunc {
+	return func(f Field, form *Form) error {
+		sibling, ok := form.fields[otherField]
+		if !ok || f.Value() != sibling.Value() {
+			return fmt.Errorf("%s must match %s.", f.Name(), otherField)
+		}
+		return nil
+	}
+}
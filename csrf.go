@@ -0,0 +1,153 @@
+package forms
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+)
+
+// CSRFFieldName is the form field and cookie name used to carry the CSRF
+// token. CSRFMiddleware also accepts the token in the X-CSRF-Token header.
+const CSRFFieldName = "_csrf"
+
+// CSRFInput is a hidden field carrying the CSRF token.
+type CSRFInput struct {
+	Input
+}
+
+// NewCSRFInput creates a hidden CSRF token field holding the given token.
+func NewCSRFInput(token string) *CSRFInput {
+	input := new(CSRFInput)
+	input.Input = newPlainInput()
+	input.typ = "hidden"
+	input.name = CSRFFieldName
+	input.value = token
+	return input
+}
+
+// EnableCSRF turns on CSRF protection for f: HTML() injects a hidden
+// "_csrf" input carrying a token bound to secret, and Load/Validate reject
+// submissions whose token doesn't match the one stored in the "_csrf"
+// cookie (see SetCSRFCookie). It reuses r's existing "_csrf" cookie if it
+// already carries a valid token for secret, so re-rendering the same
+// session's form keeps issuing the same token; otherwise it issues a fresh
+// one via SetCSRFCookie and sets it on w. Calling EnableCSRF is the only
+// step needed — it keeps the rendered token and the cookie in sync, so
+// callers don't also need SetCSRFCookie or SetCSRFToken for the common case.
+func (f *Form) EnableCSRF(w http.ResponseWriter, r *http.Request, secret []byte) *Form {
+	f.csrfSecret = secret
+
+	if cookie, err := r.Cookie(CSRFFieldName); err == nil && validCSRFToken(secret, cookie.Value) {
+		f.csrfToken = cookie.Value
+		return f
+	}
+
+	token, err := SetCSRFCookie(w, secret)
+	if err != nil {
+		return f
+	}
+	f.csrfToken = token
+	return f
+}
+
+// SetCSRFToken overrides the token HTML() embeds in the hidden "_csrf"
+// field. EnableCSRF already keeps the rendered token in sync with the
+// cookie it sets, so this is only needed when a cookie was issued some
+// other way, e.g. directly via SetCSRFCookie.
+func (f *Form) SetCSRFToken(token string) *Form {
+	f.csrfToken = token
+	return f
+}
+
+// generateCSRFToken creates a fresh token made of a random nonce and an
+// HMAC-SHA256 of that nonce keyed on secret, so a token can later be
+// verified statelessly with validCSRFToken.
+func generateCSRFToken(secret []byte) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(nonce)
+	token := append(nonce, mac.Sum(nil)...)
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// validCSRFToken reports whether token was produced by generateCSRFToken
+// for the given secret.
+func validCSRFToken(secret []byte, token string) bool {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) <= sha256.Size {
+		return false
+	}
+	nonce, sum := raw[:len(raw)-sha256.Size], raw[len(raw)-sha256.Size:]
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(nonce)
+
+	return subtle.ConstantTimeCompare(sum, mac.Sum(nil)) == 1
+}
+
+// csrfTokenMatchesCookie implements the double-submit check: submitted must
+// be a validly-signed token AND match the one stored in the "_csrf" cookie on
+// r, so a token issued for one session can't be replayed against another.
+func csrfTokenMatchesCookie(r *http.Request, secret []byte, submitted string) bool {
+	if !validCSRFToken(secret, submitted) {
+		return false
+	}
+	cookie, err := r.Cookie(CSRFFieldName)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(submitted)) == 1
+}
+
+// SetCSRFCookie issues a fresh CSRF token bound to secret, stores it on the
+// user's session via an HttpOnly cookie named CSRFFieldName, and returns the
+// token so it can be embedded in the rendered form, e.g. with NewCSRFInput.
+func SetCSRFCookie(w http.ResponseWriter, secret []byte) (string, error) {
+	token, err := generateCSRFToken(secret)
+	if err != nil {
+		return "", err
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     CSRFFieldName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	return token, nil
+}
+
+// CSRFMiddleware rejects unsafe-method requests (anything other than GET,
+// HEAD, OPTIONS or TRACE) unless the submitted token is both validly signed
+// for secret and matches the "_csrf" cookie set by SetCSRFCookie (the
+// double-submit check) — a validly-signed token alone isn't enough, since
+// any token the server ever issued would otherwise validate for every
+// session. The token is read from the X-CSRF-Token header or, if absent,
+// the "_csrf" form value.
+func CSRFMiddleware(secret []byte) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token := r.Header.Get("X-CSRF-Token")
+			if token == "" {
+				token = r.PostFormValue(CSRFFieldName)
+			}
+			if !csrfTokenMatchesCookie(r, secret, token) {
+				http.Error(w, "invalid CSRF token", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
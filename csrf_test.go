@@ -0,0 +1,133 @@
+package forms
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCSRFTokenMustMatchCookie(t *testing.T) {
+	secret := []byte("test-secret")
+
+	issuedToOther, err := generateCSRFToken(secret)
+	if err != nil {
+		t.Fatalf("generateCSRFToken: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	cookieToken, err := SetCSRFCookie(w, secret)
+	if err != nil {
+		t.Fatalf("SetCSRFCookie: %v", err)
+	}
+
+	newRequest := func(submitted string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r.PostForm = url.Values{CSRFFieldName: {submitted}}
+		for _, c := range w.Result().Cookies() {
+			r.AddCookie(c)
+		}
+		return r
+	}
+
+	if csrfTokenMatchesCookie(newRequest(issuedToOther), secret, issuedToOther) {
+		t.Fatal("a validly-signed token issued for a different session must not pass")
+	}
+	if !csrfTokenMatchesCookie(newRequest(cookieToken), secret, cookieToken) {
+		t.Fatal("a token matching the session cookie must pass")
+	}
+}
+
+func TestCSRFMiddlewareRejectsMismatchedToken(t *testing.T) {
+	secret := []byte("test-secret")
+	mismatched, err := generateCSRFToken(secret)
+	if err != nil {
+		t.Fatalf("generateCSRFToken: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	cookieToken, err := SetCSRFCookie(w, secret)
+	if err != nil {
+		t.Fatalf("SetCSRFCookie: %v", err)
+	}
+
+	handler := CSRFMiddleware(secret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.PostForm = url.Values{CSRFFieldName: {mismatched}}
+	for _, c := range w.Result().Cookies() {
+		r.AddCookie(c)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("got status %d, want %d for mismatched token", rec.Code, http.StatusForbidden)
+	}
+
+	r2 := httptest.NewRequest(http.MethodPost, "/", nil)
+	r2.PostForm = url.Values{CSRFFieldName: {cookieToken}}
+	for _, c := range w.Result().Cookies() {
+		r2.AddCookie(c)
+	}
+	rec2 := httptest.NewRecorder()
+	handler.ServeHTTP(rec2, r2)
+	if rec2.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d for matching token", rec2.Code, http.StatusOK)
+	}
+}
+
+// TestFormEnableCSRFRoundTrip exercises the natural call sequence a reader
+// would write from the Form doc comments: EnableCSRF, render, submit, load,
+// validate. A single call to EnableCSRF must be enough to make the rendered
+// token and the issued cookie match, with no separate SetCSRFCookie /
+// SetCSRFToken call required.
+func TestFormEnableCSRFRoundTrip(t *testing.T) {
+	secret := []byte("test-secret")
+
+	f := NewForm()
+	getReq := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	f.EnableCSRF(w, getReq, secret)
+
+	html := string(f.HTML())
+	if !strings.Contains(html, f.csrfToken) {
+		t.Fatalf("rendered form does not embed the CSRF token: %s", html)
+	}
+
+	postReq := httptest.NewRequest(http.MethodPost, "/", nil)
+	postReq.PostForm = url.Values{CSRFFieldName: {f.csrfToken}}
+	for _, c := range w.Result().Cookies() {
+		postReq.AddCookie(c)
+	}
+
+	f.Load(postReq)
+	if errs := f.Validate(); errs != nil {
+		t.Fatalf("expected the round-tripped submission to validate, got %v", errs)
+	}
+}
+
+// TestFormEnableCSRFReusesExistingCookie ensures a second render against the
+// same session's request doesn't rotate the token, so a page rendering the
+// form twice (e.g. on validation failure) doesn't invalidate its own cookie.
+func TestFormEnableCSRFReusesExistingCookie(t *testing.T) {
+	secret := []byte("test-secret")
+
+	w1 := httptest.NewRecorder()
+	first := NewForm()
+	first.EnableCSRF(w1, httptest.NewRequest(http.MethodGet, "/", nil), secret)
+
+	getReq2 := httptest.NewRequest(http.MethodGet, "/", nil)
+	for _, c := range w1.Result().Cookies() {
+		getReq2.AddCookie(c)
+	}
+	w2 := httptest.NewRecorder()
+	second := NewForm()
+	second.EnableCSRF(w2, getReq2, secret)
+
+	if second.csrfToken != first.csrfToken {
+		t.Fatalf("expected the existing cookie's token to be reused, got %q want %q", second.csrfToken, first.csrfToken)
+	}
+}
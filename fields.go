@@ -0,0 +1,347 @@
+package forms
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// newPlainInput creates an Input defaulting to the generic inputTemplate,
+// ready for a field type's constructor to set its own type/validators.
+func newPlainInput() Input {
+	return Input{
+		classes:  []string{},
+		attrs:    map[string]string{},
+		tmpl:     inputTemplate,
+		required: true,
+	}
+}
+
+// maxUploadMemory is the amount of request body Form.Load buffers in memory
+// before spilling uploaded files to temporary files, matching the default
+// used by net/http.Request.ParseMultipartForm.
+const maxUploadMemory = 32 << 20
+
+// EmailInput is for creating inputs of type email.
+type EmailInput struct {
+	Input
+}
+
+func isEmail(f Field, form *Form) error {
+	if f.Value() == "" {
+		return nil
+	}
+	if !emailRegexp.MatchString(f.Value()) {
+		return errors.New("Not a valid email address.")
+	}
+	return nil
+}
+
+// NewEmailInput creates a new email type input.
+func NewEmailInput() *EmailInput {
+	input := new(EmailInput)
+	input.Input = newPlainInput()
+	input.typ = "email"
+	input.validators = append(input.validators, isEmail)
+	return input
+}
+
+// URLInput is for creating inputs of type url.
+type URLInput struct {
+	Input
+}
+
+func isURL(f Field, form *Form) error {
+	if f.Value() == "" {
+		return nil
+	}
+	if _, err := url.ParseRequestURI(f.Value()); err != nil {
+		return errors.New("Not a valid URL.")
+	}
+	return nil
+}
+
+// NewURLInput creates a new url type input.
+func NewURLInput() *URLInput {
+	input := new(URLInput)
+	input.Input = newPlainInput()
+	input.typ = "url"
+	input.validators = append(input.validators, isURL)
+	return input
+}
+
+// PasswordInput is for creating inputs of type password.
+type PasswordInput struct {
+	Input
+}
+
+// NewPasswordInput creates a new password type input.
+func NewPasswordInput() *PasswordInput {
+	input := new(PasswordInput)
+	input.Input = newPlainInput()
+	input.typ = "password"
+	return input
+}
+
+// DateInput is for creating inputs of type date.
+type DateInput struct {
+	Input
+	layout string
+}
+
+// NewDateInput creates a new date type input, validated against the
+// "2006-01-02" layout by default.
+func NewDateInput() *DateInput {
+	input := new(DateInput)
+	input.Input = newPlainInput()
+	input.typ = "date"
+	input.layout = "2006-01-02"
+	input.validators = append(input.validators, input.isDate)
+	return input
+}
+
+// SetLayout changes the time.Parse layout used to validate the submitted
+// value.
+func (d *DateInput) SetLayout(layout string) *DateInput {
+	d.layout = layout
+	return d
+}
+
+func (d *DateInput) isDate(f Field, form *Form) error {
+	if f.Value() == "" {
+		return nil
+	}
+	if _, err := time.Parse(d.layout, f.Value()); err != nil {
+		return fmt.Errorf("%s is not a valid date.", f.Name())
+	}
+	return nil
+}
+
+// TimeInput is for creating inputs of type time.
+type TimeInput struct {
+	Input
+	layout string
+}
+
+// NewTimeInput creates a new time type input, validated against the
+// "15:04" layout by default.
+func NewTimeInput() *TimeInput {
+	input := new(TimeInput)
+	input.Input = newPlainInput()
+	input.typ = "time"
+	input.layout = "15:04"
+	input.validators = append(input.validators, input.isTime)
+	return input
+}
+
+// SetLayout changes the time.Parse layout used to validate the submitted
+// value.
+func (t *TimeInput) SetLayout(layout string) *TimeInput {
+	t.layout = layout
+	return t
+}
+
+func (t *TimeInput) isTime(f Field, form *Form) error {
+	if f.Value() == "" {
+		return nil
+	}
+	if _, err := time.Parse(t.layout, f.Value()); err != nil {
+		return fmt.Errorf("%s is not a valid time.", f.Name())
+	}
+	return nil
+}
+
+// DateTimeInput is for creating inputs of type datetime-local.
+type DateTimeInput struct {
+	Input
+	layout string
+}
+
+// NewDateTimeInput creates a new datetime-local type input, validated
+// against the "2006-01-02T15:04" layout by default.
+func NewDateTimeInput() *DateTimeInput {
+	input := new(DateTimeInput)
+	input.Input = newPlainInput()
+	input.typ = "datetime-local"
+	input.layout = "2006-01-02T15:04"
+	input.validators = append(input.validators, input.isDateTime)
+	return input
+}
+
+// SetLayout changes the time.Parse layout used to validate the submitted
+// value.
+func (d *DateTimeInput) SetLayout(layout string) *DateTimeInput {
+	d.layout = layout
+	return d
+}
+
+func (d *DateTimeInput) isDateTime(f Field, form *Form) error {
+	if f.Value() == "" {
+		return nil
+	}
+	if _, err := time.Parse(d.layout, f.Value()); err != nil {
+		return fmt.Errorf("%s is not a valid date/time.", f.Name())
+	}
+	return nil
+}
+
+// FloatInput is for creating inputs of type number that accept decimals.
+type FloatInput struct {
+	Input
+}
+
+func isFloat(f Field, form *Form) error {
+	if _, err := strconv.ParseFloat(f.Value(), 64); err != nil {
+		return errors.New("Not a valid number.")
+	}
+	return nil
+}
+
+// NewFloatInput creates a new floating point number type input.
+func NewFloatInput() *FloatInput {
+	input := new(FloatInput)
+	input.Input = newPlainInput()
+	input.typ = "number"
+	input.validators = append(input.validators, isFloat)
+	return input
+}
+
+// CheckboxInput is for creating inputs of type checkbox.
+type CheckboxInput struct {
+	Input
+}
+
+// NewCheckboxInput creates a new checkbox type input.
+func NewCheckboxInput() *CheckboxInput {
+	input := new(CheckboxInput)
+	input.Input = newPlainInput()
+	input.typ = "checkbox"
+	input.tmpl = checkboxTemplate
+	return input
+}
+
+// Checked reports whether the checkbox is currently checked.
+func (c *CheckboxInput) Checked() bool {
+	return c.value == "on" || c.value == "true"
+}
+
+func (c *CheckboxInput) String() string {
+	return renderTemplate(c.tmpl, fieldTemplateData{
+		Name:      c.name,
+		Class:     strings.Join(c.classes, " "),
+		AttrsHTML: renderAttrs(c.attrs),
+		Checked:   c.Checked(),
+	})
+}
+
+// Option is a value/label pair used by RadioInput and SelectInput.
+type Option struct {
+	Value string
+	Label string
+}
+
+// RadioInput is for creating a group of inputs of type radio sharing the
+// same name, one per Option.
+type RadioInput struct {
+	Input
+	options []Option
+}
+
+// NewRadioInput creates a new radio input group with the given options.
+func NewRadioInput(options ...Option) *RadioInput {
+	input := new(RadioInput)
+	input.Input = newPlainInput()
+	input.typ = "radio"
+	input.tmpl = radioTemplate
+	input.options = options
+	return input
+}
+
+func (r *RadioInput) String() string {
+	return renderTemplate(r.tmpl, fieldTemplateData{
+		Name:      r.name,
+		Value:     r.value,
+		Class:     strings.Join(r.classes, " "),
+		AttrsHTML: renderAttrs(r.attrs),
+		Options:   r.options,
+	})
+}
+
+// SelectInput is for creating a <select> dropdown out of a list of Options.
+type SelectInput struct {
+	Input
+	options []Option
+}
+
+// NewSelectInput creates a new select input with the given options.
+func NewSelectInput(options ...Option) *SelectInput {
+	input := new(SelectInput)
+	input.Input = newPlainInput()
+	input.tmpl = selectTemplate
+	input.options = options
+	return input
+}
+
+func (s *SelectInput) String() string {
+	return renderTemplate(s.tmpl, fieldTemplateData{
+		Name:      s.name,
+		Value:     s.value,
+		Class:     strings.Join(s.classes, " "),
+		AttrsHTML: renderAttrs(s.attrs),
+		Options:   s.options,
+	})
+}
+
+// TextareaField is for creating <textarea> fields.
+type TextareaField struct {
+	Input
+}
+
+// NewTextareaField creates a new textarea field.
+func NewTextareaField() *TextareaField {
+	input := new(TextareaField)
+	input.Input = newPlainInput()
+	input.tmpl = textareaTemplate
+	return input
+}
+
+func (t *TextareaField) String() string {
+	return renderTemplate(t.tmpl, fieldTemplateData{
+		Name:      t.name,
+		Value:     t.value,
+		Class:     strings.Join(t.classes, " "),
+		AttrsHTML: renderAttrs(t.attrs),
+	})
+}
+
+// FileInput is for creating inputs of type file. Form.Load populates it
+// from a multipart/form-data submission.
+type FileInput struct {
+	Input
+	file *multipart.FileHeader
+}
+
+// NewFileInput creates a new file type input.
+func NewFileInput() *FileInput {
+	input := new(FileInput)
+	input.Input = newPlainInput()
+	input.typ = "file"
+	return input
+}
+
+// SetFile stores the uploaded file header for this field.
+func (fi *FileInput) SetFile(fh *multipart.FileHeader) {
+	fi.file = fh
+	if fh != nil {
+		fi.value = fh.Filename
+	}
+}
+
+// File returns the uploaded file header, or nil if none was submitted.
+func (fi *FileInput) File() *multipart.FileHeader {
+	return fi.file
+}
@@ -1,10 +1,12 @@
 package forms
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"html/template"
 	"net/http"
+	"reflect"
 	"strconv"
 	"strings"
 )
@@ -32,33 +34,58 @@ type Input struct {
 	typ      string
 	name     string
 	value    string
+	label    string
 	classes  []string
 	min      int
 	max      int
 	required bool
 	// a map of attributes for the field
 	attrs      map[string]string
-	validators []func(v *Input) error
+	validators []ValidatorFunc
+	tmpl       *template.Template
 }
 
+// ValidatorFunc validates a field's submitted value. form is the field's
+// parent Form, passed in so validators can inspect sibling fields for
+// cross-field rules such as RequiredIf and EqualToField.
+type ValidatorFunc func(f Field, form *Form) error
+
 // Field is the common interface between all the fields
 type Field interface {
-	AddValidator(v func(*Input) error) *Input
-	Validate() *FieldError
+	AddValidator(v ValidatorFunc) *Input
+	Validate(form *Form) *FieldError
 	Name() string
 	SetName(name string)
 	Value() string
 	SetValue(v string)
+	Label() string
 	String() string
 }
 
+// cloneField returns a shallow copy of f's concrete value behind a new
+// pointer. Form.Submit and Form.HTML use it to try a request's submitted
+// value against a field's validators/template without ever writing into
+// the Field stored in f.fields, which is shared across concurrent
+// requests against the same *Form.
+func cloneField(f Field) Field {
+	v := reflect.ValueOf(f)
+	if v.Kind() != reflect.Ptr {
+		return f
+	}
+	clone := reflect.New(v.Elem().Type())
+	clone.Elem().Set(v.Elem())
+	return clone.Interface().(Field)
+}
+
 // NewInput creates a new text input field
 func NewInput() *Input {
 	i := new(Input)
 	i.classes = []string{}
 	i.attrs = map[string]string{}
 	i.typ = "text"
-	i.validators = []func(v *Input) error{}
+	i.required = true
+	i.validators = []ValidatorFunc{}
+	i.tmpl = inputTemplate
 	return i
 }
 
@@ -85,15 +112,46 @@ func (i *Input) AddAttr(key, value string) *Input {
 }
 
 // AddValidator appends the given attribute to the slice
-func (i *Input) AddValidator(v func(*Input) error) *Input {
+func (i *Input) AddValidator(v ValidatorFunc) *Input {
 	i.validators = append(i.validators, v)
 	return i
 }
 
+// SetRequired marks whether this field must be filled in. When set to
+// false and the submitted value is empty, Validate skips every validator
+// instead of running them against an empty string.
+func (i *Input) SetRequired(required bool) *Input {
+	i.required = required
+	return i
+}
+
+// Label returns the field's label text, empty if none was set.
+func (i *Input) Label() string {
+	return i.label
+}
+
+// SetLabel sets the field's label text, rendered next to the field by
+// Form.HTML.
+func (i *Input) SetLabel(label string) *Input {
+	i.label = label
+	return i
+}
+
+// SetTemplate overrides the html/template used to render this field,
+// replacing the package default for its kind.
+func (i *Input) SetTemplate(t *template.Template) *Input {
+	i.tmpl = t
+	return i
+}
+
 func (i *Input) String() string {
-	frmt := "<input type='%s' name='%s' value='%s' class='%s' %s>"
-	input := fmt.Sprintf(frmt, i.typ, i.name, i.value, strings.Join(i.classes, " "), i.FmtAttrs())
-	return input
+	return renderTemplate(i.tmpl, fieldTemplateData{
+		Type:      i.typ,
+		Name:      i.name,
+		Value:     i.value,
+		Class:     strings.Join(i.classes, " "),
+		AttrsHTML: renderAttrs(i.attrs),
+	})
 }
 
 // Value sets the given value to the field
@@ -116,17 +174,33 @@ func (i *Input) FmtAttrs() string {
 	return strings.Join(attrs, " ")
 }
 
-// Validate loops through the validation funcs and stores the errors
-func (i *Input) Validate() *FieldError {
-	var fe *FieldError
-	for _, f := range i.validators {
-		err := f(i)
-		if err != nil {
-			fe = NewFieldError(i.Name(), err)
-			break
+// Validate runs every validator against the field and joins their errors
+// into a single FieldError, so callers see every problem at once instead of
+// only the first. If the field isn't required (see SetRequired) and its
+// value is empty, validators are skipped entirely. Otherwise, if the field
+// is required and empty, Validate fails immediately without running the
+// per-type validators, since most of them (isEmail, isURL, isDate, ...)
+// treat an empty value as "nothing to check" and would otherwise let a
+// required field pass blank. form is passed to each validator so
+// cross-field rules can inspect sibling fields.
+func (i *Input) Validate(form *Form) *FieldError {
+	if i.value == "" {
+		if i.required {
+			return NewFieldError(i.Name(), fmt.Errorf("%s is required.", i.name))
 		}
+		return nil
 	}
-	return fe
+
+	var errs []error
+	for _, v := range i.validators {
+		if err := v(i, form); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return NewFieldError(i.Name(), errors.Join(errs...))
 }
 
 // TextInput is for creating inputs of type text
@@ -139,15 +213,19 @@ type IntegerInput struct {
 	Input
 }
 
-var integerValidators = []func(*Input) error{
+var integerValidators = []ValidatorFunc{
 	isInteger,
 	integerBound,
 }
 
-func integerBound(i *Input) error {
+func integerBound(f Field, form *Form) error {
 	// the validity of the integer is checked before by isInteger
+	i, ok := f.(*Input)
+	if !ok {
+		return nil
+	}
 	v, _ := strconv.ParseInt(i.value, 10, 32)
-	frmt := "%s is %s than "
+	frmt := "%s is %s than %d"
 	if int(v) < i.min {
 		return fmt.Errorf(frmt, i.name, "less", i.min)
 	}
@@ -157,8 +235,8 @@ func integerBound(i *Input) error {
 	return nil
 }
 
-func isInteger(i *Input) error {
-	if _, err := strconv.ParseInt(i.value, 10, 32); err != nil {
+func isInteger(f Field, form *Form) error {
+	if _, err := strconv.ParseInt(f.Value(), 10, 32); err != nil {
 		return errors.New("Not a valid integer.")
 	}
 	return nil
@@ -167,6 +245,9 @@ func isInteger(i *Input) error {
 // NewIntegerInput creates a new integer type input
 func NewIntegerInput() *IntegerInput {
 	input := new(IntegerInput)
+	input.required = true
+	input.tmpl = inputTemplate
+	input.attrs = map[string]string{}
 	for _, v := range integerValidators {
 		input.validators = append(input.validators, v)
 	}
@@ -178,6 +259,14 @@ type Form struct {
 	action string
 	method string
 	fields map[string]Field
+
+	// fieldTemplate wraps each rendered field with its label and error
+	// messages; defaults to defaultFieldTemplate. Override with SetTemplate.
+	fieldTemplate *template.Template
+
+	csrfSecret []byte
+	csrfToken  string
+	csrfValid  bool
 }
 
 // NewForm creates a new form
@@ -217,15 +306,107 @@ func (f *Form) Method() string {
 	return f.method
 }
 
-// HTML returns a safe HTML code of the form
-func (f *Form) HTML() template.HTML {
-	form := fmt.Sprintf("<form action='%s' method='%s'>", f.action, f.method)
-	for _, field := range f.fields {
-		form += field.String()
+// SetTemplate overrides the template used to wrap every field of f with its
+// label and error messages, replacing defaultFieldTemplate.
+func (f *Form) SetTemplate(t *template.Template) *Form {
+	f.fieldTemplate = t
+	return f
+}
+
+// HTML returns a safe HTML code of the form, rendered through html/template
+// so submitted values can't break out of their attributes. If a Submission
+// is given, the previously submitted values are shown in their fields and
+// any field errors are rendered next to them.
+func (f *Form) HTML(sub ...*Submission) template.HTML {
+	var s *Submission
+	if len(sub) > 0 {
+		s = sub[0]
+	}
+
+	tmpl := f.fieldTemplate
+	if tmpl == nil {
+		tmpl = defaultFieldTemplate
 	}
-	form += "</form>"
-	html := template.HTML(form)
-	return html
+
+	var body strings.Builder
+	for name, field := range f.fields {
+		rendered := field
+		var errs []string
+		if s != nil {
+			// Render a clone carrying the submitted value instead of
+			// mutating the shared field, which concurrent requests
+			// against this same *Form may be reading or writing.
+			clone := cloneField(field)
+			clone.SetValue(s.Value(name))
+			rendered = clone
+			errs = s.FieldErrors(name)
+		}
+
+		body.WriteString(renderTemplate(tmpl, fieldWrapperData{
+			Label:  field.Label(),
+			Input:  template.HTML(rendered.String()),
+			Errors: errs,
+		}))
+	}
+	if f.csrfSecret != nil {
+		body.WriteString(NewCSRFInput(f.csrfToken).String())
+	}
+
+	return template.HTML(renderTemplate(formTemplate, formData{
+		Action: f.action,
+		Method: f.method,
+		Body:   template.HTML(body.String()),
+	}))
+}
+
+// Submit loads the request into a fresh Submission without mutating f's
+// shared field state, so the same *Form can be reused to render and
+// validate multiple requests concurrently. If ctx already carries a
+// Submission (see WithSubmission), that one is returned unchanged instead
+// of reparsing r.
+func (f *Form) Submit(ctx context.Context, r *http.Request) *Submission {
+	if cached := SubmissionFromContext(ctx); cached != nil {
+		return cached
+	}
+
+	sub := newSubmission()
+	if r == nil {
+		return sub
+	}
+	if err := r.ParseForm(); err != nil {
+		return sub
+	}
+	sub.isSubmitted = true
+
+	// Validation runs against clones holding the submitted values, never
+	// against f.fields itself: f may be a package-level *Form shared by
+	// every request, so writing into its fields here would race with
+	// concurrent Submit/HTML/Load calls on the same Form. The clones are
+	// gathered into a shadow Form so cross-field validators (RequiredIf,
+	// EqualToField, ...) see each other's submitted values too.
+	clones := make(map[string]Field, len(f.fields))
+	for name, field := range f.fields {
+		var raw string
+		if f.method == "GET" {
+			raw = r.FormValue(name)
+		} else {
+			raw = r.PostFormValue(name)
+		}
+		sub.values[name] = raw
+
+		clone := cloneField(field)
+		clone.SetValue(raw)
+		clones[name] = clone
+	}
+	shadow := &Form{action: f.action, method: f.method, fields: clones}
+
+	for name, clone := range clones {
+		if fe := clone.Validate(shadow); fe != nil {
+			sub.SetFieldError(name, fe.Error.Error())
+		}
+	}
+
+	return sub
 }
 
 // Load the submitted form
@@ -233,18 +414,34 @@ func (f *Form) Load(r *http.Request) *Form {
 	if r == nil {
 		return f
 	}
-	err := r.ParseForm()
+
+	var err error
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		err = r.ParseMultipartForm(maxUploadMemory)
+	} else {
+		err = r.ParseForm()
+	}
 	if err != nil {
 		fmt.Println(err)
 		return f
 	}
+
 	for name, field := range f.fields {
+		if fi, ok := field.(*FileInput); ok {
+			if r.MultipartForm != nil && len(r.MultipartForm.File[name]) > 0 {
+				fi.SetFile(r.MultipartForm.File[name][0])
+			}
+			continue
+		}
 		if f.method == "GET" {
 			field.SetValue(r.FormValue(name))
 			continue
 		}
 		field.SetValue(r.PostFormValue(name))
 	}
+	if f.csrfSecret != nil {
+		f.csrfValid = csrfTokenMatchesCookie(r, f.csrfSecret, r.PostFormValue(CSRFFieldName))
+	}
 	return f
 }
 
@@ -255,10 +452,13 @@ type FormErrors map[string]*FieldError
 func (f *Form) Validate() FormErrors {
 	errs := FormErrors{}
 	for k, field := range f.fields {
-		if err := field.Validate(); err != nil {
+		if err := field.Validate(f); err != nil {
 			errs[k] = err
 		}
 	}
+	if f.csrfSecret != nil && !f.csrfValid {
+		errs[CSRFFieldName] = NewFieldError(CSRFFieldName, errors.New("Missing or invalid CSRF token."))
+	}
 	if len(errs) > 0 {
 		return errs
 	}
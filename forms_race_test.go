@@ -0,0 +1,39 @@
+package forms
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// TestFormSubmitConcurrentNoRace exercises the same *Form being submitted by
+// many goroutines at once, the normal way a package-level Form is reused
+// across requests by an http.Handler. Submit/HTML must never write into the
+// shared Field values in f.fields, or this trips under go test -race.
+func TestFormSubmitConcurrentNoRace(t *testing.T) {
+	f := NewForm()
+	email := NewEmailInput()
+	email.SetName("email")
+	f.AddInput(email)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			val := "user" + strconv.Itoa(n) + "@example.com"
+			r, _ := http.NewRequest(http.MethodPost, "/", nil)
+			r.PostForm = url.Values{"email": {val}}
+
+			sub := f.Submit(context.Background(), r)
+			if sub.Value("email") != val {
+				t.Errorf("got %q, want %q", sub.Value("email"), val)
+			}
+			_ = f.HTML(sub)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,32 @@
+package forms
+
+import "testing"
+
+func TestRequiredRejectsEmptyValue(t *testing.T) {
+	fields := []Field{
+		NewEmailInput(),
+		NewURLInput(),
+		NewDateInput(),
+		NewTimeInput(),
+		NewDateTimeInput(),
+		NewCheckboxInput(),
+		NewRadioInput(Option{Value: "a", Label: "A"}),
+		NewSelectInput(Option{Value: "a", Label: "A"}),
+		NewTextareaField(),
+	}
+	for _, f := range fields {
+		f.SetName("field")
+		if fe := f.Validate(nil); fe == nil {
+			t.Errorf("%T: required field with empty value should fail Validate", f)
+		}
+	}
+}
+
+func TestNotRequiredAllowsEmptyValue(t *testing.T) {
+	email := NewEmailInput()
+	email.SetName("email")
+	email.SetRequired(false)
+	if fe := email.Validate(nil); fe != nil {
+		t.Fatalf("non-required empty field should pass Validate, got %v", fe)
+	}
+}
@@ -0,0 +1,73 @@
+package forms
+
+import "context"
+
+type contextKey string
+
+const submissionContextKey contextKey = "forms.submission"
+
+// Submission holds the per-request state of a form submission: whether the
+// form was submitted, the raw values entered, and any validation errors.
+// Keeping this state separate from *Form lets the same form definition be
+// reused across requests without mutating shared field state.
+type Submission struct {
+	isSubmitted bool
+	values      map[string]string
+	errors      map[string][]string
+}
+
+// newSubmission creates an empty, not-yet-submitted Submission.
+func newSubmission() *Submission {
+	return &Submission{
+		values: map[string]string{},
+		errors: map[string][]string{},
+	}
+}
+
+// IsSubmitted reports whether the request carried a submission of the form.
+func (s *Submission) IsSubmitted() bool {
+	return s.isSubmitted
+}
+
+// IsValid reports whether the submission has no field errors.
+func (s *Submission) IsValid() bool {
+	return len(s.errors) == 0
+}
+
+// IsDone reports whether the form was submitted and passed validation.
+func (s *Submission) IsDone() bool {
+	return s.isSubmitted && s.IsValid()
+}
+
+// FieldHasErrors reports whether the named field has validation errors.
+func (s *Submission) FieldHasErrors(name string) bool {
+	return len(s.errors[name]) > 0
+}
+
+// FieldErrors returns the validation error messages for the named field.
+func (s *Submission) FieldErrors(name string) []string {
+	return s.errors[name]
+}
+
+// SetFieldError appends an error message to the named field.
+func (s *Submission) SetFieldError(name, msg string) {
+	s.errors[name] = append(s.errors[name], msg)
+}
+
+// Value returns the raw submitted value for the named field.
+func (s *Submission) Value(name string) string {
+	return s.values[name]
+}
+
+// WithSubmission returns a copy of ctx carrying sub, retrievable later with
+// SubmissionFromContext.
+func WithSubmission(ctx context.Context, sub *Submission) context.Context {
+	return context.WithValue(ctx, submissionContextKey, sub)
+}
+
+// SubmissionFromContext returns the Submission previously stored in ctx with
+// WithSubmission, or nil if none is present.
+func SubmissionFromContext(ctx context.Context) *Submission {
+	sub, _ := ctx.Value(submissionContextKey).(*Submission)
+	return sub
+}
@@ -0,0 +1,114 @@
+package forms
+
+import (
+	"bytes"
+	"html/template"
+	"regexp"
+)
+
+// fieldTemplateData is the data passed to a field's rendering template.
+// Every field kind shares this shape; templates simply ignore whichever
+// fields they don't need.
+type fieldTemplateData struct {
+	Type      string
+	Name      string
+	Value     string
+	Class     string
+	AttrsHTML template.HTMLAttr
+	Checked   bool
+	Options   []Option
+}
+
+// attrNameRe restricts attribute names accepted by renderAttrs. html/template
+// treats an attribute name interpolated through {{$k}} as ambiguous content
+// (it can't tell if the template author meant to emit a single attribute or
+// several), so it substitutes the ZgotmplZ sentinel instead of the name for
+// anything but the simplest identifiers. Validating names here and emitting
+// them as already-safe template.HTML avoids that entirely.
+var attrNameRe = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_:-]*$`)
+
+// renderAttrs renders a field's extra attributes (set via Input.AddAttr) as
+// `key='value'` pairs, escaping each value and dropping any key that isn't a
+// valid HTML attribute name instead of risking a broken or unsafe attribute.
+// It returns template.HTMLAttr rather than template.HTML because the result
+// is spliced into attribute-name position in the templates below, and
+// html/template's contextual escaper only trusts HTMLAttr there.
+func renderAttrs(attrs map[string]string) template.HTMLAttr {
+	var buf bytes.Buffer
+	for k, v := range attrs {
+		if !attrNameRe.MatchString(k) {
+			continue
+		}
+		buf.WriteByte(' ')
+		buf.WriteString(k)
+		buf.WriteString("='")
+		template.HTMLEscape(&buf, []byte(v))
+		buf.WriteString("'")
+	}
+	return template.HTMLAttr(buf.String())
+}
+
+// fieldWrapperData is the data passed to a Form's field-wrapping template,
+// the one overridden by Form.SetTemplate.
+type fieldWrapperData struct {
+	Label  string
+	Input  template.HTML
+	Errors []string
+}
+
+// formData is the data passed to the internal <form> wrapper template.
+type formData struct {
+	Action string
+	Method string
+	Body   template.HTML
+}
+
+var (
+	// inputTemplate is the default template for plain <input> fields
+	// (text, email, url, password, date, time, number, file, hidden, ...).
+	inputTemplate = template.Must(template.New("input").Parse(
+		`<input type='{{.Type}}' name='{{.Name}}' value='{{.Value}}' class='{{.Class}}'{{.AttrsHTML}}>`))
+
+	// checkboxTemplate is the default template for CheckboxInput.
+	checkboxTemplate = template.Must(template.New("checkbox").Parse(
+		`<input type='checkbox' name='{{.Name}}' value='on' class='{{.Class}}'{{if .Checked}} checked{{end}}{{.AttrsHTML}}>`))
+
+	// radioTemplate is the default template for RadioInput.
+	radioTemplate = template.Must(template.New("radio").Parse(
+		`{{$name := .Name}}{{$value := .Value}}{{$class := .Class}}{{$attrs := .AttrsHTML}}` +
+			`{{range .Options}}<label><input type='radio' name='{{$name}}' value='{{.Value}}' class='{{$class}}'` +
+			`{{if eq .Value $value}} checked{{end}}{{$attrs}}>{{.Label}}</label>{{end}}`))
+
+	// selectTemplate is the default template for SelectInput.
+	selectTemplate = template.Must(template.New("select").Parse(
+		`<select name='{{.Name}}' class='{{.Class}}'{{.AttrsHTML}}>` +
+			`{{$value := .Value}}{{range .Options}}<option value='{{.Value}}'{{if eq .Value $value}} selected{{end}}>{{.Label}}</option>{{end}}</select>`))
+
+	// textareaTemplate is the default template for TextareaField.
+	textareaTemplate = template.Must(template.New("textarea").Parse(
+		`<textarea name='{{.Name}}' class='{{.Class}}'{{.AttrsHTML}}>{{.Value}}</textarea>`))
+
+	// defaultFieldTemplate is the Form-level default for wrapping a
+	// rendered field with its label and error messages. Override it
+	// (per form) with Form.SetTemplate.
+	defaultFieldTemplate = template.Must(template.New("field").Parse(
+		`<div class='form-field'>{{if .Label}}<label>{{.Label}}</label>{{end}}{{.Input}}` +
+			`{{range .Errors}}<span class='form-error'>{{.}}</span>{{end}}</div>`))
+
+	// formTemplate wraps the rendered fields in the <form> tag itself.
+	formTemplate = template.Must(template.New("form").Parse(
+		`<form action='{{.Action}}' method='{{.Method}}'>{{.Body}}</form>`))
+)
+
+// renderTemplate executes t against data and returns the resulting HTML, or
+// the empty string if t is nil or execution fails.
+func renderTemplate(t *template.Template, data any) string {
+	if t == nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return ""
+	}
+	return buf.String()
+}
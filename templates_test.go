@@ -0,0 +1,35 @@
+package forms
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHyphenatedAttrNamesRenderVerbatim(t *testing.T) {
+	i := NewInput()
+	i.SetName("name")
+	i.AddAttr("data-x", "val")
+	i.AddAttr("aria-label", "Name")
+
+	out := i.String()
+	if strings.Contains(out, "ZgotmplZ") {
+		t.Fatalf("attribute name was mangled by html/template: %s", out)
+	}
+	if !strings.Contains(out, "data-x='val'") {
+		t.Fatalf("expected data-x='val' in output, got: %s", out)
+	}
+	if !strings.Contains(out, "aria-label='Name'") {
+		t.Fatalf("expected aria-label='Name' in output, got: %s", out)
+	}
+}
+
+func TestInvalidAttrNameIsDropped(t *testing.T) {
+	i := NewInput()
+	i.SetName("name")
+	i.AddAttr("not an attr", "val")
+
+	out := i.String()
+	if strings.Contains(out, "not an attr") {
+		t.Fatalf("expected invalid attribute name to be dropped, got: %s", out)
+	}
+}